@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"flag"
+	"sort"
+)
+
+// otherCommandsCategory is the trailing heading PrintUsage uses for commands
+// with no Category, once any command in the set has one.
+const otherCommandsCategory = "Other commands"
+
+// AddInCategory is equivalent to Add, additionally assigning the command to
+// category for grouping under a heading in PrintUsage.
+// Returns the added command.
+func (c *CmdSet) AddInCategory(category, usage string, flags *flag.FlagSet, handler Handler, allowArgs bool) *Cmd {
+	cmd := c.Add(usage, flags, handler, allowArgs)
+	cmd.Category = category
+	return cmd
+}
+
+// hasCategories reports whether any command in c has been assigned a Category.
+func (c *CmdSet) hasCategories() bool {
+	for _, v := range c.commands {
+		if v.Category != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedCategories returns the distinct non-empty categories in c, sorted.
+// hasOther reports whether any command has no Category at all, and so should
+// be listed separately under otherCommandsCategory.
+func (c *CmdSet) sortedCategories() (categories []string, hasOther bool) {
+	set := make(map[string]bool)
+	for _, v := range c.commands {
+		if v.Category == "" {
+			hasOther = true
+			continue
+		}
+		set[v.Category] = true
+	}
+
+	for category := range set {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	return categories, hasOther
+}
+
+// namesInCategory returns the sorted command names whose Category equals category.
+func (c *CmdSet) namesInCategory(category string) []string {
+	var names []string
+	for _, name := range c.sortedCommandNames() {
+		if c.commands[name].Category == category {
+			names = append(names, name)
+		}
+	}
+	return names
+}
@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"flag"
+	"strings"
+	"testing"
+)
+
+func TestCmdSet_PrintUsage_Categories(t *testing.T) {
+	cmd := &CmdSet{output: &strings.Builder{}}
+	cmd.AddInCategory("vcs", "does b", flag.NewFlagSet("b", flag.ContinueOnError), nil, false)
+	cmd.AddInCategory("vcs", "does a", flag.NewFlagSet("a", flag.ContinueOnError), nil, false)
+	cmd.Add("does c", flag.NewFlagSet("c", flag.ContinueOnError), nil, false)
+
+	expected := "available subcommands for cmd.test:\n" +
+		"vcs:\n" +
+		"\ta - does a\n" +
+		"\tb - does b\n" +
+		otherCommandsCategory + ":\n" +
+		"\tc - does c\n" +
+		"use \"<subcommand> --help\" for availble options of the specififc command"
+
+	cmd.PrintUsage()
+	if r := cmd.output.(*strings.Builder).String(); r != expected {
+		t.Errorf("expected %v, got %v", expected, r)
+	}
+}
+
+func TestCmdSet_AddInCategory(t *testing.T) {
+	cmd := &CmdSet{}
+	c := cmd.AddInCategory("vcs", "does a", flag.NewFlagSet("a", flag.ContinueOnError), nil, false)
+	if c.Category != "vcs" {
+		t.Errorf("expected category vcs, got %v", c.Category)
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"context"
 	"flag"
 	"fmt"
 	"io"
@@ -21,6 +22,44 @@ type Cmd struct {
 	FlagSet   *flag.FlagSet
 	AllowArgs bool
 	Handler   Handler
+	// SubCommands, if non-nil and non-empty, makes this Cmd a parent in a
+	// multi-level command tree (e.g. "mytool remote add -url=..."). When set,
+	// Parse consumes the next positional argument as the child subcommand and
+	// descends into SubCommands instead of treating this Cmd as the match.
+	SubCommands *CmdSet
+	// PreRun, if non-nil, is run by HandleCmdContext before the Handler.
+	// In a nested command tree, ancestor PreRun hooks run first, root to leaf.
+	PreRun func(*Cmd, context.Context) error
+	// PostRun, if non-nil, is run by HandleCmdContext after the Handler.
+	// In a nested command tree, ancestor PostRun hooks run last, leaf to root.
+	PostRun func(*Cmd, context.Context) error
+	// Category groups this command under a heading in PrintUsage. Set via
+	// CmdSet.AddInCategory. Commands with no Category are listed under a
+	// trailing "Other commands" heading once any category is in use.
+	Category string
+	// requiredFlags and exclusiveGroups are populated by MarkFlagRequired and
+	// MarkFlagsMutuallyExclusive, and enforced by Parse after FlagSet.Parse succeeds.
+	requiredFlags   []string
+	exclusiveGroups [][]string
+	// usageAnnotated tracks whether FlagSet.Usage has already been replaced to
+	// print required-flag and mutually-exclusive-group annotations, so repeated
+	// MarkFlagRequired/MarkFlagsMutuallyExclusive calls don't rewrap it.
+	usageAnnotated bool
+}
+
+// hasCommands reports whether c is non-nil and has at least one registered command.
+func (c *CmdSet) hasCommands() bool {
+	return c != nil && len(c.commands) > 0
+}
+
+// CommandNames returns the names of all commands registered directly on c, sorted alphabetically.
+func (c *CmdSet) CommandNames() []string {
+	return c.sortedCommandNames()
+}
+
+// Command returns the command registered under name directly on c, or nil if no such command exists.
+func (c *CmdSet) Command(name string) *Cmd {
+	return c.commands[name]
 }
 
 // A CmdSet contains a set of subcommands.
@@ -33,6 +72,21 @@ type CmdSet struct {
 	output io.Writer
 	// used to pad usage info
 	cmdNameLength int
+	// SuggestionsMinDistance is the Levenshtein distance threshold at or below
+	// which an unknown subcommand is offered as a "Did you mean?" suggestion.
+	// The actual threshold used is max(SuggestionsMinDistance, len(input)/3).
+	// A value <= 0 uses the default of 2.
+	SuggestionsMinDistance int
+	// DisableSuggestions turns off "Did you mean?" suggestions entirely.
+	DisableSuggestions bool
+}
+
+// writer returns the destination for usage and suggestion output, defaulting to os.Stderr.
+func (c *CmdSet) writer() io.Writer {
+	if c.output != nil {
+		return c.output
+	}
+	return os.Stderr
 }
 
 // Add adds a subcommand with specified usage string and flag set.
@@ -71,18 +125,33 @@ func (c *CmdSet) Visit(f func(*Cmd)) {
 }
 
 // PrintUsage prints usage information to standard error.
+// Only the commands registered directly on c are listed; a parent Cmd's
+// usage is printed separately when its own FlagSet.Parse encounters -help.
 func (c *CmdSet) PrintUsage() {
-	output := c.output
-	if output == nil {
-		output = os.Stderr
-	}
+	output := c.writer()
 	cliName := filepath.Base(os.Args[0])
 	fmt.Fprintf(output, "available subcommands for %v:\n", cliName)
 	padVerb := fmt.Sprintf("%%-%vs", c.cmdNameLength)
 
-	for _, v := range c.sortedCommandNames() {
-		paddedCmdName := fmt.Sprintf(padVerb, v)
-		fmt.Fprintf(output, "\t%v - %v\n", paddedCmdName, c.commands[v].Info)
+	printCmdNames := func(names []string) {
+		for _, name := range names {
+			paddedCmdName := fmt.Sprintf(padVerb, name)
+			fmt.Fprintf(output, "\t%v - %v\n", paddedCmdName, c.commands[name].Info)
+		}
+	}
+
+	if !c.hasCategories() {
+		printCmdNames(c.sortedCommandNames())
+	} else {
+		categories, hasOther := c.sortedCategories()
+		for _, category := range categories {
+			fmt.Fprintf(output, "%v:\n", category)
+			printCmdNames(c.namesInCategory(category))
+		}
+		if hasOther {
+			fmt.Fprintf(output, "%v:\n", otherCommandsCategory)
+			printCmdNames(c.namesInCategory(""))
+		}
 	}
 
 	fmt.Fprint(output, "use \"<subcommand> --help\" for availble options of the specififc command")
@@ -116,7 +185,11 @@ func (c *CmdSet) getSubcommand(arguments []string) (*Cmd, error) {
 	}
 
 	if subcommand == nil {
-		return nil, fmt.Errorf("invalid subcommand %q", requestedSubcommand)
+		err := fmt.Errorf("invalid subcommand %q", requestedSubcommand)
+		if names := c.suggestions(requestedSubcommand); len(names) > 0 {
+			err = &suggestionError{err: err, suggestion: fmt.Sprintf("Did you mean %s?", formatSuggestions(names))}
+		}
+		return nil, err
 	}
 
 	return subcommand, nil
@@ -125,36 +198,64 @@ func (c *CmdSet) getSubcommand(arguments []string) (*Cmd, error) {
 // Parse parses the subcommand from arguments[0] and its flags from arguments[1:] with the error handling specified by errorHandling.
 // Returns the supplied Subcommand if a match was found.
 //
+// If the matched Cmd has a non-empty SubCommands, Parse recursively descends into it,
+// treating the next positional argument as the child subcommand, and returns the deepest matched Cmd.
+//
 // Must be called after all subcommands are defined and before flags are accessed by the program.
 // If arguments is nil, will default to `os.Args[1:]`.
 func (c *CmdSet) Parse(arguments []string, errorHandling flag.ErrorHandling) (*Cmd, error) {
 	if arguments == nil {
 		arguments = os.Args[1:]
 	}
+	cmd, _, err := c.parse(arguments, errorHandling)
+	return cmd, err
+}
 
+// parse is the recursive implementation behind Parse. Alongside the deepest
+// matched Cmd, it returns the chain of matched Cmds from root to leaf, which
+// HandleCmdContext uses to run ancestor PreRun/PostRun hooks.
+func (c *CmdSet) parse(arguments []string, errorHandling flag.ErrorHandling) (*Cmd, []*Cmd, error) {
 	subcommand, err := c.getSubcommand(arguments)
 
 	if err != nil {
 		c.PrintUsage()
+		if se, ok := err.(*suggestionError); ok {
+			fmt.Fprintln(c.writer(), se.suggestion)
+		}
 		handleError(err, errorHandling)
-		return nil, err
+		return nil, nil, err
 	}
 
 	if err := subcommand.FlagSet.Parse(arguments[1:]); err != nil {
-		return subcommand, err
+		if err == flag.ErrHelp && subcommand.SubCommands.hasCommands() {
+			subcommand.SubCommands.PrintUsage()
+		}
+		return subcommand, []*Cmd{subcommand}, err
+	}
+
+	if err := subcommand.checkFlagConstraints(); err != nil {
+		subcommand.FlagSet.Usage()
+		handleError(err, errorHandling)
+		return subcommand, []*Cmd{subcommand}, err
+	}
+
+	if subcommand.SubCommands.hasCommands() {
+		leaf, chain, err := subcommand.SubCommands.parse(subcommand.FlagSet.Args(), errorHandling)
+		return leaf, append([]*Cmd{subcommand}, chain...), err
 	}
 
 	if !subcommand.AllowArgs && subcommand.FlagSet.NArg() > 0 {
 		subcommand.FlagSet.Usage()
 		err := fmt.Errorf("arguments not supported - %v", subcommand.FlagSet.Args())
 		handleError(err, errorHandling)
-		return subcommand, err
+		return subcommand, []*Cmd{subcommand}, err
 	}
 
-	return subcommand, nil
+	return subcommand, []*Cmd{subcommand}, nil
 }
 
 // HandleCmd is equivilant to Parse followed by calling the handler of the returned Cmd.
+// For a nested command tree, Parse returns the deepest matched Cmd, so the leaf handler is called.
 // If Parse fails, the reulting error is returned, otherwise the result from handle is returned.
 func (c *CmdSet) HandleCmd(arguments []string, errorHandling flag.ErrorHandling) error {
 	cmd, err := c.Parse(arguments, errorHandling)
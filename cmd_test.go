@@ -21,18 +21,39 @@ func TestCmdSet_Add(t *testing.T) {
 			flag.NewFlagSet("one", flag.ContinueOnError),
 			false,
 			handlerFunc(func(c *Cmd) error { return nil }),
+			nil,
+			nil,
+			nil,
+			"",
+			nil,
+			nil,
+			false,
 		},
 		{
 			"info for two",
 			flag.NewFlagSet("two", flag.ContinueOnError),
 			true,
 			handlerFunc(func(c *Cmd) error { return nil }),
+			nil,
+			nil,
+			nil,
+			"",
+			nil,
+			nil,
+			false,
 		},
 		{
 			"info for three",
 			flag.NewFlagSet("three", flag.ContinueOnError),
 			false,
 			handlerFunc(func(c *Cmd) error { return nil }),
+			nil,
+			nil,
+			nil,
+			"",
+			nil,
+			nil,
+			false,
 		},
 	}
 	for _, v := range cmds {
@@ -151,6 +172,29 @@ func TestCmdSet_Parse(t *testing.T) {
 
 }
 
+func TestCmdSet_ParseNested(t *testing.T) {
+	cmd := &CmdSet{}
+
+	var url string
+	addFlags := flag.NewFlagSet("add", flag.ContinueOnError)
+	addFlags.StringVar(&url, "url", "", "")
+
+	remote := cmd.Add("", flag.NewFlagSet("remote", flag.ContinueOnError), nil, false)
+	remote.SubCommands = &CmdSet{}
+	add := remote.SubCommands.Add("", addFlags, handlerFunc(func(c *Cmd) error { return nil }), false)
+
+	c, err := cmd.Parse([]string{"remote", "add", "-url=example.com"}, flag.ContinueOnError)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c != add {
+		t.Errorf("expected deepest matched Cmd %v, got %v", add, c)
+	}
+	if url != "example.com" {
+		t.Errorf("expected example.com, got %v", url)
+	}
+}
+
 func TestCmdSet_ParseError(t *testing.T) {
 	cmd := &CmdSet{}
 	cmd.Add("", flag.NewFlagSet("a", flag.ContinueOnError), nil, false)
@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// GenBashCompletion writes a bash completion script for c to w.
+// The generated script defines a "_<progname>" function that completes
+// registered subcommand names at the first argument, and falls back to
+// completing that subcommand's flags (or, if it allows extra args,
+// filenames) for later arguments.
+func (c *CmdSet) GenBashCompletion(w io.Writer) error {
+	progName := filepath.Base(os.Args[0])
+	var err error
+	write := func(format string, a ...interface{}) {
+		if err != nil {
+			return
+		}
+		_, err = fmt.Fprintf(w, format, a...)
+	}
+
+	write("# bash completion for %s\n", progName)
+	write("_%s() {\n", progName)
+	write("\tlocal cur cmd\n")
+	write("\tcur=\"${COMP_WORDS[COMP_CWORD]}\"\n")
+	write("\tcmd=\"${COMP_WORDS[1]}\"\n\n")
+	write("\tif [[ ${COMP_CWORD} -eq 1 ]]; then\n")
+	write("\t\tCOMPREPLY=( $(compgen -W %q -- \"${cur}\") )\n", strings.Join(c.sortedCommandNames(), " "))
+	write("\t\treturn\n")
+	write("\tfi\n\n")
+	write("\tcase \"${cmd}\" in\n")
+	for _, name := range c.sortedCommandNames() {
+		writeBashCaseArm(write, name, c.commands[name], 1)
+	}
+	write("\tesac\n")
+	write("}\n")
+	write("complete -F _%s %s\n", progName, progName)
+
+	return err
+}
+
+// writeBashCaseArm emits the case arm for subcommand, named name, whose
+// arguments start at COMP_WORDS[depth+1]. If subcommand has a non-empty
+// SubCommands, it recurses one level deeper instead of completing flags.
+func writeBashCaseArm(write func(string, ...interface{}), name string, subcommand *Cmd, depth int) {
+	write("\t%s)\n", name)
+	if subcommand.SubCommands.hasCommands() {
+		write("\t\tif [[ ${COMP_CWORD} -eq %d ]]; then\n", depth+1)
+		write("\t\t\tCOMPREPLY=( $(compgen -W %q -- \"${cur}\") )\n", strings.Join(subcommand.SubCommands.sortedCommandNames(), " "))
+		write("\t\t\treturn\n")
+		write("\t\tfi\n")
+		write("\t\tcase \"${COMP_WORDS[%d]}\" in\n", depth+1)
+		for _, childName := range subcommand.SubCommands.sortedCommandNames() {
+			writeBashCaseArm(write, childName, subcommand.SubCommands.commands[childName], depth+1)
+		}
+		write("\t\tesac\n")
+	} else {
+		write("\t\tif [[ \"${cur}\" == -* ]]; then\n")
+		write("\t\t\tCOMPREPLY=( $(compgen -W %q -- \"${cur}\") )\n", strings.Join(flagNames(subcommand.FlagSet), " "))
+		if subcommand.AllowArgs {
+			write("\t\telse\n")
+			write("\t\t\tCOMPREPLY=( $(compgen -f -- \"${cur}\") )\n")
+		}
+		write("\t\tfi\n")
+	}
+	write("\t\t;;\n")
+}
+
+// GenZshCompletion writes a zsh completion script for c to w.
+// The generated script registers a "_<progname>" function, via "compdef",
+// that offers registered subcommand names at the first argument and that
+// subcommand's flags (plus filenames when it allows extra args) afterwards.
+func (c *CmdSet) GenZshCompletion(w io.Writer) error {
+	progName := filepath.Base(os.Args[0])
+	var err error
+	write := func(format string, a ...interface{}) {
+		if err != nil {
+			return
+		}
+		_, err = fmt.Fprintf(w, format, a...)
+	}
+
+	write("#compdef %s\n\n", progName)
+	write("_%s() {\n", progName)
+	write("\tlocal -a subcommands\n")
+	write("\tsubcommands=(\n")
+	for _, name := range c.sortedCommandNames() {
+		info := strings.ReplaceAll(c.commands[name].Info, "'", "")
+		write("\t\t%q\n", name+":"+info)
+	}
+	write("\t)\n\n")
+	write("\tif (( CURRENT == 2 )); then\n")
+	write("\t\t_describe 'command' subcommands\n")
+	write("\t\treturn\n")
+	write("\tfi\n\n")
+	write("\tcase ${words[2]} in\n")
+	for _, name := range c.sortedCommandNames() {
+		writeZshCaseArm(write, name, c.commands[name], 2)
+	}
+	write("\tesac\n")
+	write("}\n\n")
+	write("compdef _%s %s\n", progName, progName)
+
+	return err
+}
+
+// writeZshCaseArm emits the case arm for subcommand, named name, whose
+// arguments start at words[depth+1]. If subcommand has a non-empty
+// SubCommands, it recurses one level deeper instead of completing flags.
+func writeZshCaseArm(write func(string, ...interface{}), name string, subcommand *Cmd, depth int) {
+	write("\t%s)\n", name)
+	if subcommand.SubCommands.hasCommands() {
+		write("\t\tif (( CURRENT == %d )); then\n", depth+1)
+		write("\t\t\tcompadd -- %s\n", strings.Join(subcommand.SubCommands.sortedCommandNames(), " "))
+		write("\t\t\treturn\n")
+		write("\t\tfi\n")
+		write("\t\tcase ${words[%d]} in\n", depth+1)
+		for _, childName := range subcommand.SubCommands.sortedCommandNames() {
+			writeZshCaseArm(write, childName, subcommand.SubCommands.commands[childName], depth+1)
+		}
+		write("\t\tesac\n")
+	} else {
+		if flags := flagNames(subcommand.FlagSet); len(flags) > 0 {
+			write("\t\tcompadd -- %s\n", strings.Join(flags, " "))
+		}
+		if subcommand.AllowArgs {
+			write("\t\t_files\n")
+		}
+	}
+	write("\t\t;;\n")
+}
+
+// flagNames returns every flag registered on fs, each prefixed with "-", in lexicographical order.
+func flagNames(fs *flag.FlagSet) []string {
+	var names []string
+	fs.VisitAll(func(f *flag.Flag) {
+		names = append(names, "-"+f.Name)
+	})
+	return names
+}
+
+// completionHandler implements Handler for the Cmd added by AddCompletionCmd.
+type completionHandler struct {
+	cmdSet *CmdSet
+}
+
+func (h completionHandler) Handle(c *Cmd) error {
+	switch c.FlagSet.Arg(0) {
+	case "bash":
+		return h.cmdSet.GenBashCompletion(os.Stdout)
+	case "zsh":
+		return h.cmdSet.GenZshCompletion(os.Stdout)
+	default:
+		return fmt.Errorf("unsupported shell %q, expected \"bash\" or \"zsh\"", c.FlagSet.Arg(0))
+	}
+}
+
+// AddCompletionCmd registers a "completion" subcommand on c that writes a
+// shell completion script to standard output when invoked as
+// "completion bash" or "completion zsh".
+// Returns the added Cmd.
+func (c *CmdSet) AddCompletionCmd() *Cmd {
+	return c.Add("print shell completion script, usage: completion bash|zsh", flag.NewFlagSet("completion", flag.ContinueOnError), completionHandler{c}, true)
+}
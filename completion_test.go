@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCmdSet_GenBashCompletion(t *testing.T) {
+	cs := &CmdSet{}
+	af := flag.NewFlagSet("a", flag.ContinueOnError)
+	af.String("name", "", "")
+	cs.Add("does a", af, nil, true)
+
+	var sb strings.Builder
+	if err := cs.GenBashCompletion(&sb); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	progName := filepath.Base(os.Args[0])
+	out := sb.String()
+	for _, want := range []string{"_" + progName + "()", "a)", "-name", "compgen -f"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%v", want, out)
+		}
+	}
+}
+
+func TestCmdSet_GenZshCompletion(t *testing.T) {
+	cs := &CmdSet{}
+	af := flag.NewFlagSet("a", flag.ContinueOnError)
+	af.String("name", "", "")
+	cs.Add("does a", af, nil, false)
+
+	var sb strings.Builder
+	if err := cs.GenZshCompletion(&sb); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := sb.String()
+	for _, want := range []string{"#compdef", "a:does a", "compadd -- -name"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%v", want, out)
+		}
+	}
+}
+
+func TestCmdSet_GenBashCompletion_Nested(t *testing.T) {
+	cs := &CmdSet{}
+	addFlags := flag.NewFlagSet("add", flag.ContinueOnError)
+	addFlags.String("url", "", "")
+
+	remote := cs.Add("manage remotes", flag.NewFlagSet("remote", flag.ContinueOnError), nil, false)
+	remote.SubCommands = &CmdSet{}
+	remote.SubCommands.Add("add a remote", addFlags, nil, false)
+
+	var sb strings.Builder
+	if err := cs.GenBashCompletion(&sb); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := sb.String()
+	for _, want := range []string{"remote)", "add)", "-url"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%v", want, out)
+		}
+	}
+}
+
+func TestCmdSet_GenZshCompletion_Nested(t *testing.T) {
+	cs := &CmdSet{}
+	addFlags := flag.NewFlagSet("add", flag.ContinueOnError)
+	addFlags.String("url", "", "")
+
+	remote := cs.Add("manage remotes", flag.NewFlagSet("remote", flag.ContinueOnError), nil, false)
+	remote.SubCommands = &CmdSet{}
+	remote.SubCommands.Add("add a remote", addFlags, nil, false)
+
+	var sb strings.Builder
+	if err := cs.GenZshCompletion(&sb); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	out := sb.String()
+	for _, want := range []string{"remote)", "add)", "compadd -- -url"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected output to contain %q, got:\n%v", want, out)
+		}
+	}
+}
+
+func TestCmdSet_AddCompletionCmd(t *testing.T) {
+	cs := &CmdSet{}
+	cs.AddCompletionCmd()
+
+	if err := cs.HandleCmd([]string{"completion", "fish"}, flag.ContinueOnError); err == nil {
+		t.Errorf("expected error for unsupported shell, got nil")
+	}
+
+	if err := cs.HandleCmd([]string{"completion", "bash"}, flag.ContinueOnError); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"os"
+)
+
+// HandlerContext is an optional extension of Handler. If a Cmd's Handler
+// implements HandlerContext, HandleCmdContext calls HandleContext instead of
+// Handle, passing through the context.Context. Handlers that only implement
+// Handler keep working unchanged.
+type HandlerContext interface {
+	HandleContext(ctx context.Context, c *Cmd) error
+}
+
+// HandleCmdContext is equivalent to Parse followed by running, for the matched
+// command: every ancestor's PreRun (root to leaf), then the Handler, then
+// every ancestor's PostRun (leaf to root). Any error short-circuits the
+// remaining hooks and the handler, and is returned immediately.
+//
+// If the matched Handler implements HandlerContext, HandleContext is called
+// instead of Handle.
+func (c *CmdSet) HandleCmdContext(ctx context.Context, arguments []string, errorHandling flag.ErrorHandling) error {
+	if arguments == nil {
+		arguments = os.Args[1:]
+	}
+
+	leaf, chain, err := c.parse(arguments, errorHandling)
+	if err != nil {
+		return err
+	}
+
+	for _, ancestor := range chain {
+		if ancestor.PreRun == nil {
+			continue
+		}
+		if err := ancestor.PreRun(ancestor, ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := handle(ctx, leaf); err != nil {
+		return err
+	}
+
+	for i := len(chain) - 1; i >= 0; i-- {
+		ancestor := chain[i]
+		if ancestor.PostRun == nil {
+			continue
+		}
+		if err := ancestor.PostRun(ancestor, ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func handle(ctx context.Context, c *Cmd) error {
+	if hc, ok := c.Handler.(HandlerContext); ok {
+		return hc.HandleContext(ctx, c)
+	}
+	return c.Handler.Handle(c)
+}
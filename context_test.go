@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"testing"
+)
+
+type handlerContextFunc func(context.Context, *Cmd) error
+
+func (h handlerContextFunc) Handle(c *Cmd) error { panic("unexpected call to Handle") }
+func (h handlerContextFunc) HandleContext(ctx context.Context, c *Cmd) error {
+	return h(ctx, c)
+}
+
+func TestCmdSet_HandleCmdContext_Order(t *testing.T) {
+	cmd := &CmdSet{}
+
+	var order []string
+
+	remote := cmd.Add("", flag.NewFlagSet("remote", flag.ContinueOnError), nil, false)
+	remote.PreRun = func(c *Cmd, ctx context.Context) error { order = append(order, "remote-pre"); return nil }
+	remote.PostRun = func(c *Cmd, ctx context.Context) error { order = append(order, "remote-post"); return nil }
+	remote.SubCommands = &CmdSet{}
+
+	add := remote.SubCommands.Add("", flag.NewFlagSet("add", flag.ContinueOnError), handlerContextFunc(func(ctx context.Context, c *Cmd) error {
+		order = append(order, "add-handle")
+		return nil
+	}), false)
+	add.PreRun = func(c *Cmd, ctx context.Context) error { order = append(order, "add-pre"); return nil }
+	add.PostRun = func(c *Cmd, ctx context.Context) error { order = append(order, "add-post"); return nil }
+
+	if err := cmd.HandleCmdContext(context.Background(), []string{"remote", "add"}, flag.ContinueOnError); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{"remote-pre", "add-pre", "add-handle", "add-post", "remote-post"}
+	if len(order) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, order)
+	}
+	for i := range expected {
+		if order[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, order)
+			break
+		}
+	}
+}
+
+func TestCmdSet_HandleCmdContext_PreRunError(t *testing.T) {
+	cmd := &CmdSet{}
+
+	handlerCalled := false
+	a := cmd.Add("", flag.NewFlagSet("a", flag.ContinueOnError), handlerFunc(func(c *Cmd) error {
+		handlerCalled = true
+		return nil
+	}), false)
+	a.PreRun = func(c *Cmd, ctx context.Context) error { return context.Canceled }
+
+	if err := cmd.HandleCmdContext(context.Background(), []string{"a"}, flag.ContinueOnError); err != context.Canceled {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+	if handlerCalled {
+		t.Errorf("expected handler not to be called after PreRun error")
+	}
+}
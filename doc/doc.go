@@ -0,0 +1,156 @@
+// Package doc generates markdown and man-page documentation from a cmd.CmdSet.
+package doc
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"meblum/cmd"
+)
+
+// ManHeader holds the metadata rendered into a man page's .TH header line and footer.
+type ManHeader struct {
+	// Section is the man section the pages belong to, e.g. "1".
+	Section string
+	// Source is shown in the page footer, e.g. "mytool 1.0".
+	Source string
+	// Manual is the manual name shown in the page footer, e.g. "mytool Manual".
+	Manual string
+}
+
+// GenMarkdown walks cs, recursing into nested subcommand trees (see
+// cmd.Cmd.SubCommands), and writes one markdown file per subcommand into dir.
+// Each file is named after the program name and the command's full path
+// joined with "_" (e.g. "mytool_remote_add.md"), links back to its parent
+// command's page when it is nested, and links out to any child pages.
+func GenMarkdown(cs *cmd.CmdSet, dir string) error {
+	return walk(cs, nil, func(path []string, c *cmd.Cmd) error {
+		return genMarkdownPage(dir, path, c)
+	})
+}
+
+// GenMan walks cs, recursing into nested subcommand trees, and writes one
+// groff man page per subcommand into dir, following the standard
+// .TH/.SH NAME/.SH SYNOPSIS/.SH DESCRIPTION/.SH OPTIONS layout. Each file is
+// named after the program name and the command's full path joined with "-"
+// (e.g. "mytool-remote-add.1"), so it can be installed as "man mytool-remote-add".
+func GenMan(cs *cmd.CmdSet, dir string, hdr *ManHeader) error {
+	if hdr == nil {
+		return fmt.Errorf("doc: GenMan: hdr must not be nil")
+	}
+	return walk(cs, nil, func(path []string, c *cmd.Cmd) error {
+		return genManPage(dir, path, c, hdr)
+	})
+}
+
+// walk calls fn for every command in cs, recursing depth-first into nested
+// subcommand trees. The path passed to fn holds the command names from the
+// top-level command down to the current one; the program name is not included.
+func walk(cs *cmd.CmdSet, path []string, fn func(path []string, c *cmd.Cmd) error) error {
+	for _, name := range cs.CommandNames() {
+		c := cs.Command(name)
+		childPath := append(append([]string{}, path...), name)
+
+		if err := fn(childPath, c); err != nil {
+			return err
+		}
+		if c.SubCommands != nil {
+			if err := walk(c.SubCommands, childPath, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// fullPath returns path prefixed with the running program's name.
+func fullPath(path []string) []string {
+	return append([]string{filepath.Base(os.Args[0])}, path...)
+}
+
+func genMarkdownPage(dir string, path []string, c *cmd.Cmd) error {
+	full := fullPath(path)
+	fileName := strings.Join(full, "_") + ".md"
+
+	f, err := os.Create(filepath.Join(dir, fileName))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fmt.Fprintf(f, "## %s\n\n", strings.Join(full, " "))
+	fmt.Fprintf(f, "%s\n\n", c.Info)
+
+	if len(path) > 1 {
+		parent := full[:len(full)-1]
+		fmt.Fprintf(f, "### Parent command\n\n* [%s](%s)\n\n", strings.Join(parent, " "), strings.Join(parent, "_")+".md")
+	}
+
+	if flags := flagUsages(c.FlagSet); len(flags) > 0 {
+		fmt.Fprint(f, "### Options\n\n```\n")
+		for _, u := range flags {
+			fmt.Fprintf(f, "%s\n", u)
+		}
+		fmt.Fprint(f, "```\n\n")
+	}
+
+	if c.SubCommands != nil {
+		if names := c.SubCommands.CommandNames(); len(names) > 0 {
+			fmt.Fprint(f, "### Subcommands\n\n")
+			for _, name := range names {
+				child := append(append([]string{}, full...), name)
+				fmt.Fprintf(f, "* [%s](%s)\n", strings.Join(child, " "), strings.Join(child, "_")+".md")
+			}
+			fmt.Fprintln(f)
+		}
+	}
+
+	return nil
+}
+
+func genManPage(dir string, path []string, c *cmd.Cmd, hdr *ManHeader) error {
+	full := fullPath(path)
+	name := strings.Join(full, "-")
+	fileName := name + "." + hdr.Section
+
+	f, err := os.Create(filepath.Join(dir, fileName))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	fmt.Fprintf(f, ".TH %s %q %q %q %q\n", strings.ToUpper(name), hdr.Section, "", hdr.Source, hdr.Manual)
+	fmt.Fprintf(f, ".SH NAME\n%s \\- %s\n", strings.Join(full, " "), c.Info)
+	fmt.Fprintf(f, ".SH SYNOPSIS\n\\fB%s\\fR", strings.Join(full, " "))
+	c.FlagSet.VisitAll(func(fl *flag.Flag) {
+		fmt.Fprintf(f, " [\\fB-%s\\fR]", fl.Name)
+	})
+	fmt.Fprintln(f)
+	fmt.Fprintf(f, ".SH DESCRIPTION\n%s\n", c.Info)
+
+	if flags := flagUsages(c.FlagSet); len(flags) > 0 {
+		fmt.Fprint(f, ".SH OPTIONS\n")
+		c.FlagSet.VisitAll(func(fl *flag.Flag) {
+			fmt.Fprintf(f, ".TP\n\\fB-%s\\fR\n%s (default %q)\n", fl.Name, fl.Usage, fl.DefValue)
+		})
+	}
+
+	if len(path) > 0 {
+		parent := full[:len(full)-1]
+		fmt.Fprintf(f, ".SH SEE ALSO\n%s(%s)\n", strings.Join(parent, "-"), hdr.Section)
+	}
+
+	return nil
+}
+
+// flagUsages renders one "-name\n\tusage (default \"value\")" entry per flag registered on fs.
+func flagUsages(fs *flag.FlagSet) []string {
+	var lines []string
+	fs.VisitAll(func(f *flag.Flag) {
+		lines = append(lines, fmt.Sprintf("-%s\n\t%s (default %q)", f.Name, f.Usage, f.DefValue))
+	})
+	return lines
+}
@@ -0,0 +1,76 @@
+package doc
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"meblum/cmd"
+)
+
+func buildCmdSet() *cmd.CmdSet {
+	cs := &cmd.CmdSet{}
+
+	addFlags := flag.NewFlagSet("add", flag.ContinueOnError)
+	addFlags.String("url", "", "remote url")
+
+	remote := cs.Add("manage remotes", flag.NewFlagSet("remote", flag.ContinueOnError), nil, false)
+	remote.SubCommands = &cmd.CmdSet{}
+	remote.SubCommands.Add("add a remote", addFlags, nil, false)
+
+	return cs
+}
+
+func TestGenMarkdown(t *testing.T) {
+	dir := t.TempDir()
+	if err := GenMarkdown(buildCmdSet(), dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	progName := filepath.Base(os.Args[0])
+
+	remotePage, err := os.ReadFile(filepath.Join(dir, progName+"_remote.md"))
+	if err != nil {
+		t.Fatalf("expected remote page: %v", err)
+	}
+	if !strings.Contains(string(remotePage), "### Subcommands") || !strings.Contains(string(remotePage), "add") {
+		t.Errorf("expected remote page to link to add subcommand, got:\n%v", string(remotePage))
+	}
+
+	addPage, err := os.ReadFile(filepath.Join(dir, progName+"_remote_add.md"))
+	if err != nil {
+		t.Fatalf("expected remote_add page: %v", err)
+	}
+	if !strings.Contains(string(addPage), "-url") || !strings.Contains(string(addPage), "### Parent command") {
+		t.Errorf("expected add page to document -url flag and link to parent, got:\n%v", string(addPage))
+	}
+}
+
+func TestGenMan_NilHeader(t *testing.T) {
+	if err := GenMan(buildCmdSet(), t.TempDir(), nil); err == nil {
+		t.Errorf("expected error for nil ManHeader, got nil")
+	}
+}
+
+func TestGenMan(t *testing.T) {
+	dir := t.TempDir()
+	hdr := &ManHeader{Section: "1", Source: "mytool 1.0", Manual: "mytool Manual"}
+	if err := GenMan(buildCmdSet(), dir, hdr); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	progName := filepath.Base(os.Args[0])
+
+	addPage, err := os.ReadFile(filepath.Join(dir, progName+"-remote-add.1"))
+	if err != nil {
+		t.Fatalf("expected remote-add man page: %v", err)
+	}
+	content := string(addPage)
+	for _, want := range []string{".TH", ".SH NAME", ".SH SYNOPSIS", ".SH DESCRIPTION", ".SH OPTIONS", ".SH SEE ALSO"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected man page to contain %q, got:\n%v", want, content)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// MarkFlagRequired records that name must be supplied when c's flags are
+// parsed. Enforced by Parse after FlagSet.Parse succeeds, using FlagSet.Visit
+// to determine which flags were actually set; if name is missing, Parse
+// fails with an error routed through handleError.
+func (c *Cmd) MarkFlagRequired(name string) {
+	c.requiredFlags = append(c.requiredFlags, name)
+	c.annotateUsage()
+}
+
+// MarkFlagsMutuallyExclusive records that at most one flag in names may be
+// supplied together. Enforced the same way as MarkFlagRequired.
+func (c *Cmd) MarkFlagsMutuallyExclusive(names ...string) {
+	c.exclusiveGroups = append(c.exclusiveGroups, names)
+	c.annotateUsage()
+}
+
+// checkFlagConstraints enforces c's required flags and mutually-exclusive
+// groups against the flags actually set during the last FlagSet.Parse.
+func (c *Cmd) checkFlagConstraints() error {
+	set := make(map[string]bool)
+	c.FlagSet.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	var missing []string
+	for _, name := range c.requiredFlags {
+		if !set[name] {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("required flag(s) %s not set", quoteFlagNames(missing))
+	}
+
+	for _, group := range c.exclusiveGroups {
+		var supplied []string
+		for _, name := range group {
+			if set[name] {
+				supplied = append(supplied, name)
+			}
+		}
+		if len(supplied) > 1 {
+			return fmt.Errorf("flags %s are mutually exclusive, only one of them can be set", quoteFlagNames(supplied))
+		}
+	}
+
+	return nil
+}
+
+// annotateUsage replaces c.FlagSet.Usage, once, with a printer that lists
+// each flag's default usage - appending "(required)" for required flags -
+// followed by a "one of: -a, -b, -c" line per mutually-exclusive group.
+func (c *Cmd) annotateUsage() {
+	if c.usageAnnotated {
+		return
+	}
+	c.usageAnnotated = true
+	c.FlagSet.Usage = c.printAnnotatedUsage
+}
+
+func (c *Cmd) printAnnotatedUsage() {
+	out := c.FlagSet.Output()
+	if c.FlagSet.Name() == "" {
+		fmt.Fprint(out, "Usage:\n")
+	} else {
+		fmt.Fprintf(out, "Usage of %s:\n", c.FlagSet.Name())
+	}
+
+	required := make(map[string]bool)
+	for _, name := range c.requiredFlags {
+		required[name] = true
+	}
+
+	c.FlagSet.VisitAll(func(f *flag.Flag) {
+		usage := f.Usage
+		if required[f.Name] {
+			usage += " (required)"
+		}
+		fmt.Fprintf(out, "  -%s\n\t%s (default %q)\n", f.Name, usage, f.DefValue)
+	})
+
+	for _, group := range c.exclusiveGroups {
+		fmt.Fprintf(out, "  one of: %s\n", strings.Join(prefixFlagNames(group), ", "))
+	}
+}
+
+// quoteFlagNames renders names as a quoted, "-"-prefixed, comma separated list.
+func quoteFlagNames(names []string) string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = fmt.Sprintf("%q", "-"+n)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// prefixFlagNames prefixes every name in names with "-".
+func prefixFlagNames(names []string) []string {
+	prefixed := make([]string, len(names))
+	for i, n := range names {
+		prefixed[i] = "-" + n
+	}
+	return prefixed
+}
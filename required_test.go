@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"flag"
+	"strings"
+	"testing"
+)
+
+func TestCmdSet_ParseRequiredFlag(t *testing.T) {
+	cmd := &CmdSet{}
+	af := flag.NewFlagSet("a", flag.ContinueOnError)
+	af.String("name", "", "")
+	a := cmd.Add("", af, nil, false)
+	a.MarkFlagRequired("name")
+
+	if _, err := cmd.Parse([]string{"a"}, flag.ContinueOnError); err == nil || !strings.Contains(err.Error(), `"-name"`) {
+		t.Errorf(`expected error mentioning "-name", got %v`, err)
+	}
+
+	if _, err := cmd.Parse([]string{"a", "-name=x"}, flag.ContinueOnError); err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+}
+
+func TestCmdSet_ParseMutuallyExclusiveFlags(t *testing.T) {
+	cmd := &CmdSet{}
+	af := flag.NewFlagSet("a", flag.ContinueOnError)
+	af.String("x", "", "")
+	af.String("y", "", "")
+	a := cmd.Add("", af, nil, false)
+	a.MarkFlagsMutuallyExclusive("x", "y")
+
+	if _, err := cmd.Parse([]string{"a", "-x=1", "-y=2"}, flag.ContinueOnError); err == nil {
+		t.Errorf("expected error for mutually exclusive flags, got nil")
+	}
+
+	af2 := flag.NewFlagSet("a", flag.ContinueOnError)
+	af2.String("x", "", "")
+	af2.String("y", "", "")
+	a.FlagSet = af2
+
+	if _, err := cmd.Parse([]string{"a", "-x=1"}, flag.ContinueOnError); err != nil {
+		t.Errorf("expected no error when only one exclusive flag set, got %v", err)
+	}
+}
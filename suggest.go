@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// defaultSuggestionsMinDistance is used when CmdSet.SuggestionsMinDistance <= 0.
+const defaultSuggestionsMinDistance = 2
+
+// suggestionError wraps an "invalid subcommand" error with a "Did you mean?"
+// suggestion, surfaced both from Error() and, separately, appended to the
+// usage output printed alongside it.
+type suggestionError struct {
+	err        error
+	suggestion string
+}
+
+func (e *suggestionError) Error() string { return e.err.Error() + ". " + e.suggestion }
+func (e *suggestionError) Unwrap() error { return e.err }
+
+// suggestions returns the registered command names closest to name by
+// Levenshtein distance, provided the minimum distance found is within
+// max(SuggestionsMinDistance, len(name)/3). Ties are all returned,
+// alphabetically sorted and capped at 3.
+func (c *CmdSet) suggestions(name string) []string {
+	if c.DisableSuggestions || len(c.commands) == 0 {
+		return nil
+	}
+
+	threshold := c.SuggestionsMinDistance
+	if threshold <= 0 {
+		threshold = defaultSuggestionsMinDistance
+	}
+	if d := len(name) / 3; d > threshold {
+		threshold = d
+	}
+
+	minDistance := -1
+	var matches []string
+	for _, candidate := range c.sortedCommandNames() {
+		d := levenshteinDistance(strings.ToLower(name), strings.ToLower(candidate))
+		switch {
+		case minDistance == -1 || d < minDistance:
+			minDistance = d
+			matches = []string{candidate}
+		case d == minDistance:
+			matches = append(matches, candidate)
+		}
+	}
+
+	if minDistance == -1 || minDistance > threshold {
+		return nil
+	}
+
+	sort.Strings(matches)
+	if len(matches) > 3 {
+		matches = matches[:3]
+	}
+	return matches
+}
+
+// formatSuggestions renders names as a human-readable list, e.g.
+// `"add"`, `"add" or "addr"`, or `"add", "addr" or "address"`.
+func formatSuggestions(names []string) string {
+	quoted := make([]string, len(names))
+	for i, n := range names {
+		quoted[i] = fmt.Sprintf("%q", n)
+	}
+	if len(quoted) == 1 {
+		return quoted[0]
+	}
+	return strings.Join(quoted[:len(quoted)-1], ", ") + " or " + quoted[len(quoted)-1]
+}
+
+// levenshteinDistance computes the edit distance between a and b over runes,
+// using the standard two-row dynamic-programming algorithm with a cost of 1
+// for each insertion, deletion, or substitution.
+func levenshteinDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min3(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+
+	return prev[len(rb)]
+}
+
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
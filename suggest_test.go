@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"flag"
+	"strings"
+	"testing"
+)
+
+func TestCmdSet_ParseSuggestion(t *testing.T) {
+	cmd := &CmdSet{output: &strings.Builder{}}
+	cmd.Add("", flag.NewFlagSet("add", flag.ContinueOnError), nil, false)
+	cmd.Add("", flag.NewFlagSet("remove", flag.ContinueOnError), nil, false)
+
+	_, err := cmd.Parse([]string{"ad"}, flag.ContinueOnError)
+	if err == nil || !strings.Contains(err.Error(), `Did you mean "add"?`) {
+		t.Errorf(`expected error containing Did you mean "add"?, got %v`, err)
+	}
+
+	out := cmd.output.(*strings.Builder).String()
+	if !strings.Contains(out, `Did you mean "add"?`) {
+		t.Errorf(`expected usage output to contain Did you mean "add"?, got %v`, out)
+	}
+}
+
+func TestCmdSet_ParseSuggestion_TooFar(t *testing.T) {
+	cmd := &CmdSet{}
+	cmd.Add("", flag.NewFlagSet("add", flag.ContinueOnError), nil, false)
+
+	_, err := cmd.Parse([]string{"zzzzzzzz"}, flag.ContinueOnError)
+	if err == nil || strings.Contains(err.Error(), "Did you mean") {
+		t.Errorf("expected no suggestion, got %v", err)
+	}
+}
+
+func TestCmdSet_ParseSuggestion_Disabled(t *testing.T) {
+	cmd := &CmdSet{DisableSuggestions: true}
+	cmd.Add("", flag.NewFlagSet("add", flag.ContinueOnError), nil, false)
+
+	_, err := cmd.Parse([]string{"ad"}, flag.ContinueOnError)
+	if err == nil || strings.Contains(err.Error(), "Did you mean") {
+		t.Errorf("expected no suggestion when disabled, got %v", err)
+	}
+}
+
+func TestLevenshteinDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"add", "add", 0},
+		{"add", "ad", 1},
+		{"kitten", "sitting", 3},
+	}
+	for _, tt := range tests {
+		if d := levenshteinDistance(tt.a, tt.b); d != tt.expected {
+			t.Errorf("levenshteinDistance(%q, %q) = %v, want %v", tt.a, tt.b, d, tt.expected)
+		}
+	}
+}